@@ -0,0 +1,149 @@
+package config
+
+import (
+    "fmt"
+    "os"
+    "reflect"
+    "strconv"
+    "strings"
+    "time"
+)
+
+// applyEnvOverrides обходит структуру cfg через reflection и для каждого поля
+// с тегом `env:"MY_VAR"` подставляет значение из os.Getenv, если оно задано,
+// либо значение из тега `default:"..."`, если в YAML и окружении пусто.
+// Рекурсивно спускается во вложенные структуры.
+func applyEnvOverrides(cfg any) error {
+    v := reflect.ValueOf(cfg)
+    if v.Kind() != reflect.Ptr || v.IsNil() {
+        return fmt.Errorf("applyEnvOverrides: cfg must be a non-nil pointer")
+    }
+    return applyEnvOverridesValue(v.Elem())
+}
+
+func applyEnvOverridesValue(v reflect.Value) error {
+    if v.Kind() != reflect.Struct {
+        return nil
+    }
+    t := v.Type()
+    for i := 0; i < t.NumField(); i++ {
+        field := t.Field(i)
+        fv := v.Field(i)
+        if !fv.CanSet() {
+            continue
+        }
+
+        // Спускаемся во вложенные структуры (и указатели на структуры).
+        if field.Type.Kind() == reflect.Struct {
+            if err := applyEnvOverridesValue(fv); err != nil {
+                return err
+            }
+        } else if field.Type.Kind() == reflect.Ptr && field.Type.Elem().Kind() == reflect.Struct {
+            if fv.IsNil() {
+                // Поле оставлено nil (не сконфигурированная опциональная секция) —
+                // выделяем его только если внутри действительно что-то применится,
+                // иначе ломаем типичную проверку `if cfg.Section != nil`.
+                tmp := reflect.New(field.Type.Elem())
+                if err := applyEnvOverridesValue(tmp.Elem()); err != nil {
+                    return err
+                }
+                if !tmp.Elem().IsZero() {
+                    fv.Set(tmp)
+                }
+            } else if err := applyEnvOverridesValue(fv.Elem()); err != nil {
+                return err
+            }
+        }
+
+        envKey, ok := field.Tag.Lookup("env")
+        if !ok || envKey == "" {
+            continue
+        }
+
+        raw, present := os.LookupEnv(envKey)
+        if !present {
+            if !isZero(fv) {
+                continue
+            }
+            def, hasDefault := field.Tag.Lookup("default")
+            if !hasDefault {
+                continue
+            }
+            raw = def
+        }
+
+        if err := setFieldFromString(fv, raw); err != nil {
+            return fmt.Errorf("env %s: %w", envKey, err)
+        }
+    }
+    return nil
+}
+
+func isZero(v reflect.Value) bool {
+    return v.IsZero()
+}
+
+func setFieldFromString(fv reflect.Value, raw string) error {
+    // time.Duration — частный случай int64, разбирается отдельно.
+    if fv.Type() == reflect.TypeOf(time.Duration(0)) {
+        d, err := time.ParseDuration(raw)
+        if err != nil {
+            return err
+        }
+        fv.SetInt(int64(d))
+        return nil
+    }
+
+    switch fv.Kind() {
+    case reflect.String:
+        fv.SetString(raw)
+    case reflect.Bool:
+        b, err := strconv.ParseBool(raw)
+        if err != nil {
+            return err
+        }
+        fv.SetBool(b)
+    case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+        n, err := strconv.ParseInt(raw, 10, 64)
+        if err != nil {
+            return err
+        }
+        fv.SetInt(n)
+    case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+        n, err := strconv.ParseUint(raw, 10, 64)
+        if err != nil {
+            return err
+        }
+        fv.SetUint(n)
+    case reflect.Float32, reflect.Float64:
+        f, err := strconv.ParseFloat(raw, 64)
+        if err != nil {
+            return err
+        }
+        fv.SetFloat(f)
+    case reflect.Slice:
+        parts := splitAndTrim(raw)
+        slice := reflect.MakeSlice(fv.Type(), len(parts), len(parts))
+        for i, p := range parts {
+            if err := setFieldFromString(slice.Index(i), p); err != nil {
+                return err
+            }
+        }
+        fv.Set(slice)
+    default:
+        return fmt.Errorf("unsupported field kind %s for env override", fv.Kind())
+    }
+    return nil
+}
+
+func splitAndTrim(raw string) []string {
+    if strings.TrimSpace(raw) == "" {
+        return nil
+    }
+    parts := strings.Split(raw, ",")
+    out := make([]string, 0, len(parts))
+    for _, p := range parts {
+        out = append(out, strings.TrimSpace(p))
+    }
+    return out
+}
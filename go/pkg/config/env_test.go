@@ -0,0 +1,118 @@
+package config
+
+import (
+    "testing"
+    "time"
+)
+
+type envScalars struct {
+    Str  string        `env:"TEST_ENV_STR"`
+    Num  int           `env:"TEST_ENV_NUM"`
+    UNum uint          `env:"TEST_ENV_UNUM"`
+    Flag bool          `env:"TEST_ENV_FLAG"`
+    Flt  float64       `env:"TEST_ENV_FLT"`
+    Dur  time.Duration `env:"TEST_ENV_DUR"`
+    List []string      `env:"TEST_ENV_LIST"`
+    Def  string        `env:"TEST_ENV_MISSING" default:"fallback"`
+}
+
+func TestApplyEnvOverridesScalarTypes(t *testing.T) {
+    t.Setenv("TEST_ENV_STR", "hello")
+    t.Setenv("TEST_ENV_NUM", "-7")
+    t.Setenv("TEST_ENV_UNUM", "7")
+    t.Setenv("TEST_ENV_FLAG", "true")
+    t.Setenv("TEST_ENV_FLT", "3.5")
+    t.Setenv("TEST_ENV_DUR", "2s")
+    t.Setenv("TEST_ENV_LIST", "a, b ,c")
+
+    var cfg envScalars
+    if err := applyEnvOverrides(&cfg); err != nil {
+        t.Fatalf("applyEnvOverrides: %v", err)
+    }
+
+    if cfg.Str != "hello" {
+        t.Errorf("Str = %q, want %q", cfg.Str, "hello")
+    }
+    if cfg.Num != -7 {
+        t.Errorf("Num = %d, want %d", cfg.Num, -7)
+    }
+    if cfg.UNum != 7 {
+        t.Errorf("UNum = %d, want %d", cfg.UNum, 7)
+    }
+    if !cfg.Flag {
+        t.Errorf("Flag = %v, want true", cfg.Flag)
+    }
+    if cfg.Flt != 3.5 {
+        t.Errorf("Flt = %v, want %v", cfg.Flt, 3.5)
+    }
+    if cfg.Dur != 2*time.Second {
+        t.Errorf("Dur = %v, want %v", cfg.Dur, 2*time.Second)
+    }
+    wantList := []string{"a", "b", "c"}
+    if len(cfg.List) != len(wantList) {
+        t.Fatalf("List = %v, want %v", cfg.List, wantList)
+    }
+    for i, v := range wantList {
+        if cfg.List[i] != v {
+            t.Errorf("List[%d] = %q, want %q", i, cfg.List[i], v)
+        }
+    }
+    if cfg.Def != "fallback" {
+        t.Errorf("Def = %q, want %q (from default tag)", cfg.Def, "fallback")
+    }
+}
+
+func TestApplyEnvOverridesYAMLValueWinsWhenEnvUnset(t *testing.T) {
+    cfg := envScalars{Str: "from-yaml"}
+    if err := applyEnvOverrides(&cfg); err != nil {
+        t.Fatalf("applyEnvOverrides: %v", err)
+    }
+    if cfg.Str != "from-yaml" {
+        t.Errorf("Str = %q, want YAML value %q to survive untouched env", cfg.Str, "from-yaml")
+    }
+}
+
+type envSub struct {
+    Value string `env:"TEST_ENV_SUB_VALUE"`
+}
+
+type envWithOptionalSection struct {
+    Sub *envSub
+}
+
+func TestApplyEnvOverridesLeavesUnconfiguredOptionalSectionNil(t *testing.T) {
+    var cfg envWithOptionalSection
+    if err := applyEnvOverrides(&cfg); err != nil {
+        t.Fatalf("applyEnvOverrides: %v", err)
+    }
+    if cfg.Sub != nil {
+        t.Errorf("Sub = %+v, want nil when neither YAML nor env configured it", cfg.Sub)
+    }
+}
+
+func TestApplyEnvOverridesAllocatesOptionalSectionWhenEnvSet(t *testing.T) {
+    t.Setenv("TEST_ENV_SUB_VALUE", "configured")
+
+    var cfg envWithOptionalSection
+    if err := applyEnvOverrides(&cfg); err != nil {
+        t.Fatalf("applyEnvOverrides: %v", err)
+    }
+    if cfg.Sub == nil {
+        t.Fatal("Sub = nil, want allocated section since an env var targets a field inside it")
+    }
+    if cfg.Sub.Value != "configured" {
+        t.Errorf("Sub.Value = %q, want %q", cfg.Sub.Value, "configured")
+    }
+}
+
+func TestApplyEnvOverridesRecursesIntoAlreadySetOptionalSection(t *testing.T) {
+    t.Setenv("TEST_ENV_SUB_VALUE", "overridden")
+
+    cfg := envWithOptionalSection{Sub: &envSub{}}
+    if err := applyEnvOverrides(&cfg); err != nil {
+        t.Fatalf("applyEnvOverrides: %v", err)
+    }
+    if cfg.Sub.Value != "overridden" {
+        t.Errorf("Sub.Value = %q, want %q", cfg.Sub.Value, "overridden")
+    }
+}
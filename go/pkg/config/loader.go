@@ -0,0 +1,214 @@
+package config
+
+import (
+    "fmt"
+    "os"
+    "path/filepath"
+
+    "gopkg.in/yaml.v3"
+)
+
+// Loader собирает конфиг из нескольких источников в фиксированном порядке
+// приоритета (от низшего к высшему):
+//
+//  1. значения, уже установленные в cfg на момент вызова Load (компилируемые
+//     в приложение дефолты);
+//  2. базовый config.yml, найденный через findConfigFile/AddSearchPath;
+//  3. оверлей config.<APP_ENV>.yml рядом с базовым файлом, если APP_ENV задан
+//     и такой файл существует;
+//  4. файлы, явно добавленные через AddFile, в порядке добавления;
+//  5. переменные окружения (см. applyEnvOverrides);
+//  6. явные программные оверрайды, добавленные через AddOverrides.
+//
+// YAML-слои (2-4) мёрджатся рекурсивно по map: вложенные map сливаются
+// по ключам, остальные значения (скаляры, слайсы) заменяются целиком —
+// это позволяет оверлею поменять одно вложенное поле, не дублируя весь файл.
+type Loader struct {
+    searchPaths []string
+    files       []string
+    overrides   map[string]any
+}
+
+// NewLoader создаёт пустой Loader с поведением по умолчанию, эквивалентным
+// старому LoadConfig.
+func NewLoader() *Loader {
+    return &Loader{}
+}
+
+// AddSearchPath добавляет дополнительный каталог, в котором искать config.yml
+// до того, как включится обычный обход вверх по дереву каталогов.
+func (l *Loader) AddSearchPath(dir string) *Loader {
+    l.searchPaths = append(l.searchPaths, dir)
+    return l
+}
+
+// AddFile добавляет ещё один YAML-файл, который мёрджится поверх базового
+// config.yml (и его env-оверлея, если есть) в порядке добавления.
+func (l *Loader) AddFile(path string) *Loader {
+    l.files = append(l.files, path)
+    return l
+}
+
+// AddOverrides добавляет явные программные оверрайды, применяемые последними,
+// после файлов и переменных окружения.
+func (l *Loader) AddOverrides(overrides map[string]any) *Loader {
+    if l.overrides == nil {
+        l.overrides = map[string]any{}
+    }
+    deepMerge(l.overrides, overrides)
+    return l
+}
+
+// Load выполняет слияние источников в cfg согласно приоритету, описанному
+// в комментарии к Loader, прогоняет результат через Validate и делает его
+// доступным через Current[T].
+func (l *Loader) Load(cfg any) error {
+    if err := l.build(cfg); err != nil {
+        return err
+    }
+    if err := Validate(cfg); err != nil {
+        return err
+    }
+    setCurrent(cfg)
+    return nil
+}
+
+// build выполняет слияние источников в cfg (см. Load), но без Validate и
+// без публикации через setCurrent — это общий шаг, который Load и
+// WatchLoader прогоняют на каждую перезагрузку, чтобы оверлей и AddFile-слои
+// не терялись при hot-reload.
+func (l *Loader) build(cfg any) error {
+    merged := map[string]any{}
+    if err := marshalInto(merged, cfg); err != nil {
+        return err
+    }
+
+    basePath, err := l.basePath()
+    if err != nil {
+        return err
+    }
+    if err := mergeYAMLFile(merged, basePath); err != nil {
+        return err
+    }
+
+    if overlay, ok := l.overlayPath(basePath); ok {
+        if err := mergeYAMLFile(merged, overlay); err != nil {
+            return err
+        }
+    }
+
+    for _, f := range l.files {
+        if err := mergeYAMLFile(merged, f); err != nil {
+            return err
+        }
+    }
+
+    if err := unmarshalFrom(merged, cfg); err != nil {
+        return err
+    }
+
+    if err := applyEnvOverrides(cfg); err != nil {
+        return err
+    }
+
+    if len(l.overrides) > 0 {
+        cfgMap := map[string]any{}
+        if err := marshalInto(cfgMap, cfg); err != nil {
+            return err
+        }
+        deepMerge(cfgMap, l.overrides)
+        if err := unmarshalFrom(cfgMap, cfg); err != nil {
+            return err
+        }
+    }
+
+    return nil
+}
+
+// basePath resolves the base config.yml this Loader would read from.
+func (l *Loader) basePath() (string, error) {
+    return findConfigFileIn("config.yml", l.searchPaths)
+}
+
+// overlayPath returns the APP_ENV overlay path next to basePath, and whether
+// it currently exists on disk.
+func (l *Loader) overlayPath(basePath string) (string, bool) {
+    env := os.Getenv("APP_ENV")
+    if env == "" {
+        return "", false
+    }
+    overlay := filepath.Join(filepath.Dir(basePath), fmt.Sprintf("config.%s.yml", env))
+    fi, err := os.Stat(overlay)
+    if err != nil || fi.IsDir() {
+        return "", false
+    }
+    return overlay, true
+}
+
+// findConfigFileIn extends findConfigFile with additional search paths that
+// are checked (in order) before falling back to the upward directory walk.
+func findConfigFileIn(filename string, extraPaths []string) (string, error) {
+    if configPath := os.Getenv("CONFIG_PATH"); configPath != "" {
+        return findConfigFile(filename)
+    }
+    for _, dir := range extraPaths {
+        candidate := filepath.Join(dir, filename)
+        if fi, err := os.Stat(candidate); err == nil && !fi.IsDir() {
+            return candidate, nil
+        }
+    }
+    return findConfigFile(filename)
+}
+
+func mergeYAMLFile(dst map[string]any, path string) error {
+    data, err := os.ReadFile(path)
+    if err != nil {
+        return err
+    }
+    rendered, err := renderTemplate(data, path)
+    if err != nil {
+        return err
+    }
+    var layer map[string]any
+    if err := yaml.Unmarshal(rendered, &layer); err != nil {
+        return err
+    }
+    deepMerge(dst, layer)
+    return nil
+}
+
+// deepMerge copies src into dst, recursing into nested maps so a layer only
+// needs to specify the keys it changes; any other value type in src replaces
+// the corresponding value in dst wholesale.
+func deepMerge(dst, src map[string]any) {
+    for k, v := range src {
+        if srcMap, ok := v.(map[string]any); ok {
+            if dstMap, ok := dst[k].(map[string]any); ok {
+                deepMerge(dstMap, srcMap)
+                continue
+            }
+        }
+        dst[k] = v
+    }
+}
+
+func marshalInto(dst map[string]any, v any) error {
+    data, err := yaml.Marshal(v)
+    if err != nil {
+        return err
+    }
+    var m map[string]any
+    if err := yaml.Unmarshal(data, &m); err != nil {
+        return err
+    }
+    deepMerge(dst, m)
+    return nil
+}
+
+func unmarshalFrom(m map[string]any, cfg any) error {
+    data, err := yaml.Marshal(m)
+    if err != nil {
+        return err
+    }
+    return yaml.Unmarshal(data, cfg)
+}
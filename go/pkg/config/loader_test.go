@@ -0,0 +1,129 @@
+package config
+
+import (
+    "os"
+    "path/filepath"
+    "reflect"
+    "testing"
+)
+
+func TestDeepMergeNestedMapsMergeByKey(t *testing.T) {
+    dst := map[string]any{
+        "arbitrage": map[string]any{
+            "max_workers": 4,
+            "endpoint":    "http://base.example",
+        },
+        "name": "base",
+    }
+    src := map[string]any{
+        "arbitrage": map[string]any{
+            "endpoint": "http://overlay.example",
+        },
+    }
+
+    deepMerge(dst, src)
+
+    want := map[string]any{
+        "arbitrage": map[string]any{
+            "max_workers": 4,
+            "endpoint":    "http://overlay.example",
+        },
+        "name": "base",
+    }
+    if !reflect.DeepEqual(dst, want) {
+        t.Errorf("deepMerge result = %#v, want %#v", dst, want)
+    }
+}
+
+func TestDeepMergeReplacesScalarsAndSlicesWholesale(t *testing.T) {
+    dst := map[string]any{
+        "tags": []any{"a", "b"},
+        "name": "base",
+    }
+    src := map[string]any{
+        "tags": []any{"c"},
+        "name": "overridden",
+    }
+
+    deepMerge(dst, src)
+
+    if !reflect.DeepEqual(dst["tags"], []any{"c"}) {
+        t.Errorf("tags = %#v, want slice replaced wholesale", dst["tags"])
+    }
+    if dst["name"] != "overridden" {
+        t.Errorf("name = %v, want %q", dst["name"], "overridden")
+    }
+}
+
+type loaderTestConfig struct {
+    Name      string `yaml:"name"`
+    Arbitrage struct {
+        MaxWorkers int    `yaml:"max_workers"`
+        Endpoint   string `yaml:"endpoint"`
+    } `yaml:"arbitrage"`
+}
+
+func writeFile(t *testing.T, path, contents string) {
+    t.Helper()
+    if err := os.WriteFile(path, []byte(contents), 0o644); err != nil {
+        t.Fatalf("writing %s: %v", path, err)
+    }
+}
+
+// TestLoaderPrecedenceBaseOverlayThenAddFile exercises the YAML file layers
+// (2-4) of the precedence order documented on Loader: base config.yml, an
+// APP_ENV overlay next to it, then an explicit AddFile layer on top — each only touching the keys
+// it cares about, relying on deepMerge to preserve the rest.
+func TestLoaderPrecedenceBaseOverlayThenAddFile(t *testing.T) {
+    t.Setenv("CONFIG_PATH", "")
+    t.Setenv("APP_ENV", "staging")
+
+    dir := t.TempDir()
+    writeFile(t, filepath.Join(dir, "config.yml"), ""+
+        "name: base\n"+
+        "arbitrage:\n"+
+        "  max_workers: 4\n"+
+        "  endpoint: http://base.example\n")
+    writeFile(t, filepath.Join(dir, "config.staging.yml"), ""+
+        "arbitrage:\n"+
+        "  endpoint: http://staging.example\n")
+
+    extraPath := filepath.Join(dir, "extra.yml")
+    writeFile(t, extraPath, "name: overridden-by-file\n")
+
+    var cfg loaderTestConfig
+    err := NewLoader().AddSearchPath(dir).AddFile(extraPath).Load(&cfg)
+    if err != nil {
+        t.Fatalf("Load: %v", err)
+    }
+
+    if cfg.Name != "overridden-by-file" {
+        t.Errorf("Name = %q, want %q (AddFile should win)", cfg.Name, "overridden-by-file")
+    }
+    if cfg.Arbitrage.Endpoint != "http://staging.example" {
+        t.Errorf("Arbitrage.Endpoint = %q, want %q (overlay should win over base)", cfg.Arbitrage.Endpoint, "http://staging.example")
+    }
+    if cfg.Arbitrage.MaxWorkers != 4 {
+        t.Errorf("Arbitrage.MaxWorkers = %d, want %d (untouched by overlay/AddFile, preserved from base)", cfg.Arbitrage.MaxWorkers, 4)
+    }
+}
+
+func TestLoaderAddOverridesWinsOverEverything(t *testing.T) {
+    t.Setenv("CONFIG_PATH", "")
+    t.Setenv("APP_ENV", "")
+
+    dir := t.TempDir()
+    writeFile(t, filepath.Join(dir, "config.yml"), "name: base\n")
+
+    var cfg loaderTestConfig
+    err := NewLoader().
+        AddSearchPath(dir).
+        AddOverrides(map[string]any{"name": "programmatic"}).
+        Load(&cfg)
+    if err != nil {
+        t.Fatalf("Load: %v", err)
+    }
+    if cfg.Name != "programmatic" {
+        t.Errorf("Name = %q, want %q (explicit override should win)", cfg.Name, "programmatic")
+    }
+}
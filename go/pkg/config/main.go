@@ -2,7 +2,6 @@ package config
 
 import (
     "errors"
-    "gopkg.in/yaml.v3"
     "os"
     "path/filepath"
 )
@@ -11,8 +10,12 @@ import (
 func findConfigFile(filename string) (string, error) {
     configPath := os.Getenv("CONFIG_PATH")
     if configPath != "" {
-        if fi, err := os.Stat(configPath); err == nil && !fi.IsDir() {
-            return configPath, nil
+        resolved, err := resolveRemoteConfig(configPath)
+        if err != nil {
+            return "", err
+        }
+        if fi, err := os.Stat(resolved); err == nil && !fi.IsDir() {
+            return resolved, nil
         }
         return "", errors.New("CONFIG_PATH is set but file not found: " + configPath)
     }
@@ -34,15 +37,9 @@ func findConfigFile(filename string) (string, error) {
     return "", errors.New("Config file not found. Please specify CONFIG_PATH or place config.yml above the working directory")
 }
 
-// Загрузка YAML-конфига в структуру
+// LoadConfig загружает YAML-конфиг в структуру cfg. Это тонкая обёртка над
+// Loader с поведением по умолчанию (см. Loader для многослойной загрузки
+// с APP_ENV-оверлеями, дополнительными файлами и программными оверрайдами).
 func LoadConfig(cfg any) error {
-    path, err := findConfigFile("config.yml")
-    if err != nil {
-        return err
-    }
-    data, err := os.ReadFile(path)
-    if err != nil {
-        return err
-    }
-    return yaml.Unmarshal(data, cfg)
+    return NewLoader().Load(cfg)
 }
@@ -0,0 +1,126 @@
+package config
+
+import (
+    "crypto/sha256"
+    "encoding/hex"
+    "net/http"
+    "net/http/httptest"
+    "os"
+    "path/filepath"
+    "testing"
+)
+
+func TestResolveRemoteConfigPlainPathPassesThrough(t *testing.T) {
+    got, err := resolveRemoteConfig("/etc/app/config.yml")
+    if err != nil {
+        t.Fatalf("resolveRemoteConfig: %v", err)
+    }
+    if got != "/etc/app/config.yml" {
+        t.Errorf("got %q, want input unchanged for a plain path", got)
+    }
+}
+
+func TestResolveRemoteConfigFetchesHTTPAndCachesToTempFile(t *testing.T) {
+    const body = "name: from-remote\n"
+    srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+        w.Write([]byte(body))
+    }))
+    defer srv.Close()
+
+    path, err := resolveRemoteConfig(srv.URL)
+    if err != nil {
+        t.Fatalf("resolveRemoteConfig: %v", err)
+    }
+    defer os.Remove(path)
+
+    data, err := os.ReadFile(path)
+    if err != nil {
+        t.Fatalf("reading cached temp file: %v", err)
+    }
+    if string(data) != body {
+        t.Errorf("cached content = %q, want %q", data, body)
+    }
+}
+
+func TestResolveRemoteConfigVerifiesSHA256(t *testing.T) {
+    const body = "name: from-remote\n"
+    sum := sha256.Sum256([]byte(body))
+    hash := hex.EncodeToString(sum[:])
+
+    srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+        w.Write([]byte(body))
+    }))
+    defer srv.Close()
+
+    t.Run("matching hash succeeds", func(t *testing.T) {
+        t.Setenv("CONFIG_SHA256", hash)
+        path, err := resolveRemoteConfig(srv.URL)
+        if err != nil {
+            t.Fatalf("resolveRemoteConfig: %v", err)
+        }
+        defer os.Remove(path)
+    })
+
+    t.Run("mismatched hash fails", func(t *testing.T) {
+        t.Setenv("CONFIG_SHA256", "deadbeef")
+        if _, err := resolveRemoteConfig(srv.URL); err == nil {
+            t.Fatal("resolveRemoteConfig: want error on sha256 mismatch, got nil")
+        }
+    })
+}
+
+func TestResolveRemoteConfigNon200Status(t *testing.T) {
+    srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+        w.WriteHeader(http.StatusInternalServerError)
+    }))
+    defer srv.Close()
+
+    if _, err := resolveRemoteConfig(srv.URL); err == nil {
+        t.Fatal("resolveRemoteConfig: want error on non-200 status, got nil")
+    }
+}
+
+func TestRenderTemplateEnvFunc(t *testing.T) {
+    t.Setenv("CONFIG_TEST_VALUE", "hello")
+    dir := t.TempDir()
+    out, err := renderTemplate([]byte(`name: {{ env "CONFIG_TEST_VALUE" }}`+"\n"), filepath.Join(dir, "config.yml"))
+    if err != nil {
+        t.Fatalf("renderTemplate: %v", err)
+    }
+    if got, want := string(out), "name: hello\n"; got != want {
+        t.Errorf("rendered = %q, want %q", got, want)
+    }
+}
+
+func TestRenderTemplateFileFunc(t *testing.T) {
+    dir := t.TempDir()
+    writeFile(t, filepath.Join(dir, "secret.txt"), "s3cr3t")
+
+    out, err := renderTemplate([]byte(`token: {{ file "secret.txt" }}`+"\n"), filepath.Join(dir, "config.yml"))
+    if err != nil {
+        t.Fatalf("renderTemplate: %v", err)
+    }
+    if got, want := string(out), "token: s3cr3t\n"; got != want {
+        t.Errorf("rendered = %q, want %q", got, want)
+    }
+}
+
+func TestRenderTemplateIncludeFuncIsRecursive(t *testing.T) {
+    dir := t.TempDir()
+    writeFile(t, filepath.Join(dir, "inner.yml"), `leaf: {{ env "CONFIG_TEST_VALUE" }}`)
+    writeFile(t, filepath.Join(dir, "outer.yml"), `nested: |
+  {{ include "inner.yml" }}`)
+    t.Setenv("CONFIG_TEST_VALUE", "deep-value")
+
+    data, err := os.ReadFile(filepath.Join(dir, "outer.yml"))
+    if err != nil {
+        t.Fatalf("reading outer.yml: %v", err)
+    }
+    out, err := renderTemplate(data, filepath.Join(dir, "outer.yml"))
+    if err != nil {
+        t.Fatalf("renderTemplate: %v", err)
+    }
+    if got, want := string(out), "nested: |\n  leaf: deep-value"; got != want {
+        t.Errorf("rendered = %q, want %q", got, want)
+    }
+}
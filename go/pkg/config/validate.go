@@ -0,0 +1,308 @@
+package config
+
+import (
+    "fmt"
+    "os"
+    "reflect"
+    "strconv"
+    "strings"
+
+    "github.com/go-playground/validator/v10"
+    "gopkg.in/yaml.v3"
+)
+
+// FilePath помечает поле конфига как путь, который после загрузки конфига
+// должен указывать на существующий файл, например сертификат TLS или файл
+// с API-токеном.
+type FilePath string
+
+// Existing помечает поле конфига как путь (файл или каталог), который после
+// загрузки конфига должен существовать.
+type Existing string
+
+// ValidationError — одно непрошедшее проверку поле, о котором сообщает
+// Validate, адресованное его YAML-путём через точку (например,
+// "arbitrage.sources[2].endpoint").
+type ValidationError struct {
+    Path    string
+    Message string
+}
+
+func (e ValidationError) Error() string {
+    return fmt.Sprintf("%s: %s", e.Path, e.Message)
+}
+
+// ValidationErrors агрегирует все непрошедшие проверку поля из одного
+// вызова Validate.
+type ValidationErrors []ValidationError
+
+func (e ValidationErrors) Error() string {
+    parts := make([]string, len(e))
+    for i, v := range e {
+        parts[i] = v.Error()
+    }
+    return strings.Join(parts, "; ")
+}
+
+// Validate проверяет cfg по тегам `validate:"..."` (синтаксис go-playground/
+// validator, например `validate:"required,url,oneof=a b"`) и убеждается,
+// что каждое поле FilePath/Existing указывает на действительно существующий
+// путь. Все ошибки возвращаются вместе как ValidationErrors, каждая с
+// dotted YAML-путём проблемного поля.
+func Validate(cfg any) error {
+    var errs ValidationErrors
+
+    v := validator.New()
+    v.RegisterTagNameFunc(yamlTagNameFunc)
+    if err := v.Struct(cfg); err != nil {
+        fieldErrs, ok := err.(validator.ValidationErrors)
+        if !ok {
+            return err
+        }
+        for _, fe := range fieldErrs {
+            errs = append(errs, ValidationError{
+                Path:    trimNamespaceRoot(fe.Namespace()),
+                Message: validationTagMessage(fe),
+            })
+        }
+    }
+
+    checkExistingPaths(reflect.ValueOf(cfg), "", &errs)
+
+    if len(errs) == 0 {
+        return nil
+    }
+    return errs
+}
+
+func yamlTagNameFunc(f reflect.StructField) string {
+    name := yamlFieldName(f)
+    if name == "-" {
+        return ""
+    }
+    return name
+}
+
+func yamlFieldName(f reflect.StructField) string {
+    tag := f.Tag.Get("yaml")
+    if tag != "" {
+        if idx := strings.Index(tag, ","); idx >= 0 {
+            tag = tag[:idx]
+        }
+        if tag != "" {
+            return tag
+        }
+    }
+    return strings.ToLower(f.Name)
+}
+
+// trimNamespaceRoot отбрасывает начальный сегмент "TypeName.", который
+// validator добавляет к каждому namespace — он не относится к собственной
+// YAML-структуре конфига.
+func trimNamespaceRoot(ns string) string {
+    if idx := strings.Index(ns, "."); idx >= 0 {
+        return ns[idx+1:]
+    }
+    return ns
+}
+
+func validationTagMessage(fe validator.FieldError) string {
+    switch fe.Tag() {
+    case "required":
+        return "is required"
+    case "url":
+        return "must be a valid URL"
+    case "oneof":
+        return fmt.Sprintf("must be one of [%s]", fe.Param())
+    case "email":
+        return "must be a valid email address"
+    case "min":
+        return fmt.Sprintf("must be at least %s", fe.Param())
+    case "max":
+        return fmt.Sprintf("must be at most %s", fe.Param())
+    case "gte":
+        return fmt.Sprintf("must be >= %s", fe.Param())
+    case "lte":
+        return fmt.Sprintf("must be <= %s", fe.Param())
+    default:
+        return fmt.Sprintf("failed validation %q", fe.Tag())
+    }
+}
+
+// checkExistingPaths обходит cfg в поисках полей FilePath/Existing и
+// добавляет ValidationError для тех, что заданы, но не резолвятся через
+// os.Stat.
+func checkExistingPaths(v reflect.Value, path string, errs *ValidationErrors) {
+    if v.Kind() == reflect.Ptr {
+        if v.IsNil() {
+            return
+        }
+        v = v.Elem()
+    }
+    if v.Kind() != reflect.Struct {
+        return
+    }
+
+    t := v.Type()
+    for i := 0; i < t.NumField(); i++ {
+        field := t.Field(i)
+        fv := v.Field(i)
+        if !fv.CanInterface() {
+            continue
+        }
+        fieldPath := yamlFieldName(field)
+        if path != "" {
+            fieldPath = path + "." + fieldPath
+        }
+
+        switch fv.Kind() {
+        case reflect.String:
+            if fv.Type() == reflect.TypeOf(FilePath("")) || fv.Type() == reflect.TypeOf(Existing("")) {
+                checkExists(fv.String(), fieldPath, errs)
+            }
+        case reflect.Struct:
+            checkExistingPaths(fv, fieldPath, errs)
+        case reflect.Ptr:
+            checkExistingPaths(fv, fieldPath, errs)
+        case reflect.Slice:
+            for j := 0; j < fv.Len(); j++ {
+                checkExistingPaths(fv.Index(j), fmt.Sprintf("%s[%d]", fieldPath, j), errs)
+            }
+        }
+    }
+}
+
+func checkExists(path, fieldPath string, errs *ValidationErrors) {
+    if path == "" {
+        return
+    }
+    if _, err := os.Stat(path); err != nil {
+        *errs = append(*errs, ValidationError{
+            Path:    fieldPath,
+            Message: fmt.Sprintf("references %q which does not exist: %s", path, err),
+        })
+    }
+}
+
+// Dump сериализует действующий конфиг обратно в YAML, заменяя значения
+// полей с тегом `secret:"true"` фиксированным плейсхолдером — для отладки
+// в духе --print-config без утечки учётных данных.
+func Dump(cfg any) ([]byte, error) {
+    data, err := yaml.Marshal(cfg)
+    if err != nil {
+        return nil, err
+    }
+    var m map[string]any
+    if err := yaml.Unmarshal(data, &m); err != nil {
+        return nil, err
+    }
+
+    t := reflect.TypeOf(cfg)
+    var secretPaths []string
+    collectSecretPaths(t, "", &secretPaths)
+    for _, p := range secretPaths {
+        maskPath(m, strings.Split(p, "."))
+    }
+
+    return yaml.Marshal(m)
+}
+
+const maskedPlaceholder = "********"
+
+func collectSecretPaths(t reflect.Type, prefix string, out *[]string) {
+    if t == nil {
+        return
+    }
+    if t.Kind() == reflect.Ptr {
+        t = t.Elem()
+    }
+    if t.Kind() != reflect.Struct {
+        return
+    }
+
+    for i := 0; i < t.NumField(); i++ {
+        field := t.Field(i)
+        name := yamlFieldName(field)
+        path := name
+        if prefix != "" {
+            path = prefix + "." + name
+        }
+
+        if secret, _ := strconv.ParseBool(field.Tag.Get("secret")); secret {
+            *out = append(*out, path)
+        }
+
+        ft := field.Type
+        if ft.Kind() == reflect.Ptr {
+            ft = ft.Elem()
+        }
+        switch ft.Kind() {
+        case reflect.Struct:
+            collectSecretPaths(ft, path, out)
+        case reflect.Slice:
+            et := ft.Elem()
+            if et.Kind() == reflect.Ptr {
+                et = et.Elem()
+            }
+            if et.Kind() == reflect.Struct {
+                collectSecretPaths(et, path+"[]", out)
+            }
+        }
+    }
+}
+
+func maskValue(m map[string]any, key string, val any) {
+    switch v := val.(type) {
+    case nil:
+        return
+    case string:
+        if v != "" {
+            m[key] = maskedPlaceholder
+        }
+    default:
+        // Поле с тегом secret, не являющееся строкой (например, `APIKeys
+        // []string `secret:"true"`` или map токенов), маскируется целиком,
+        // а не оставляется как есть — единственного скаляра для редактирования
+        // здесь нет.
+        m[key] = maskedPlaceholder
+    }
+}
+
+// maskPath маскирует значение по dotted-пути (полученному из
+// collectSecretPaths) внутри обобщённой YAML map, разворачивая сегменты
+// "key[]" по всем элементам декодированного слайса.
+func maskPath(node any, parts []string) {
+    if len(parts) == 0 {
+        return
+    }
+    m, ok := node.(map[string]any)
+    if !ok {
+        return
+    }
+
+    part := parts[0]
+    isSlice := strings.HasSuffix(part, "[]")
+    key := strings.TrimSuffix(part, "[]")
+
+    val, ok := m[key]
+    if !ok {
+        return
+    }
+
+    if isSlice {
+        items, ok := val.([]any)
+        if !ok {
+            return
+        }
+        for _, item := range items {
+            maskPath(item, parts[1:])
+        }
+        return
+    }
+
+    if len(parts) == 1 {
+        maskValue(m, key, val)
+        return
+    }
+    maskPath(val, parts[1:])
+}
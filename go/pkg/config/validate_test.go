@@ -0,0 +1,142 @@
+package config
+
+import (
+    "testing"
+
+    "gopkg.in/yaml.v3"
+)
+
+type validateTestConfig struct {
+    Endpoint string   `yaml:"endpoint" validate:"required,url"`
+    Mode     string   `yaml:"mode" validate:"oneof=a b c"`
+    Cert     FilePath `yaml:"cert"`
+}
+
+func TestValidateAggregatesFailingFieldsWithYAMLPaths(t *testing.T) {
+    cfg := validateTestConfig{
+        Endpoint: "",
+        Mode:     "z",
+        Cert:     FilePath("/nonexistent/cert.pem"),
+    }
+
+    err := Validate(&cfg)
+    if err == nil {
+        t.Fatal("Validate returned nil, want aggregated errors")
+    }
+    verrs, ok := err.(ValidationErrors)
+    if !ok {
+        t.Fatalf("Validate error type = %T, want ValidationErrors", err)
+    }
+
+    byPath := map[string]string{}
+    for _, v := range verrs {
+        byPath[v.Path] = v.Message
+    }
+
+    if _, ok := byPath["endpoint"]; !ok {
+        t.Errorf("expected a failure for path %q, got %v", "endpoint", byPath)
+    }
+    if _, ok := byPath["mode"]; !ok {
+        t.Errorf("expected a failure for path %q, got %v", "mode", byPath)
+    }
+    if msg, ok := byPath["cert"]; !ok {
+        t.Errorf("expected a failure for path %q, got %v", "cert", byPath)
+    } else if msg == "" {
+        t.Errorf("cert failure message is empty")
+    }
+}
+
+func TestValidatePassesForWellFormedConfig(t *testing.T) {
+    cfg := validateTestConfig{
+        Endpoint: "http://example.com",
+        Mode:     "a",
+        Cert:     "",
+    }
+    if err := Validate(&cfg); err != nil {
+        t.Fatalf("Validate: %v", err)
+    }
+}
+
+type dumpTestSource struct {
+    Endpoint string `yaml:"endpoint"`
+    Token    string `yaml:"token" secret:"true"`
+}
+
+type dumpTestConfig struct {
+    Name     string           `yaml:"name"`
+    Password string           `yaml:"password" secret:"true"`
+    APIKeys  []string         `yaml:"api_keys" secret:"true"`
+    Sources  []dumpTestSource `yaml:"sources"`
+}
+
+func TestDumpMasksStringSecret(t *testing.T) {
+    cfg := dumpTestConfig{Name: "svc", Password: "hunter2"}
+
+    data, err := Dump(&cfg)
+    if err != nil {
+        t.Fatalf("Dump: %v", err)
+    }
+    var m map[string]any
+    if err := yaml.Unmarshal(data, &m); err != nil {
+        t.Fatalf("unmarshal dump output: %v", err)
+    }
+
+    if m["name"] != "svc" {
+        t.Errorf("name = %v, want %q untouched", m["name"], "svc")
+    }
+    if m["password"] != maskedPlaceholder {
+        t.Errorf("password = %v, want masked", m["password"])
+    }
+}
+
+func TestDumpMasksSliceSecretWholesale(t *testing.T) {
+    cfg := dumpTestConfig{APIKeys: []string{"key1", "key2"}}
+
+    data, err := Dump(&cfg)
+    if err != nil {
+        t.Fatalf("Dump: %v", err)
+    }
+    var m map[string]any
+    if err := yaml.Unmarshal(data, &m); err != nil {
+        t.Fatalf("unmarshal dump output: %v", err)
+    }
+
+    if m["api_keys"] != maskedPlaceholder {
+        t.Errorf("api_keys = %v, want masked wholesale since it's a non-string secret field", m["api_keys"])
+    }
+}
+
+func TestDumpMasksSecretFieldNestedInSliceOfStructs(t *testing.T) {
+    cfg := dumpTestConfig{
+        Sources: []dumpTestSource{
+            {Endpoint: "http://a.example", Token: "tok-a"},
+            {Endpoint: "http://b.example", Token: "tok-b"},
+        },
+    }
+
+    data, err := Dump(&cfg)
+    if err != nil {
+        t.Fatalf("Dump: %v", err)
+    }
+    var m map[string]any
+    if err := yaml.Unmarshal(data, &m); err != nil {
+        t.Fatalf("unmarshal dump output: %v", err)
+    }
+
+    sources, ok := m["sources"].([]any)
+    if !ok || len(sources) != 2 {
+        t.Fatalf("sources = %#v, want 2 entries", m["sources"])
+    }
+    for i, raw := range sources {
+        src, ok := raw.(map[string]any)
+        if !ok {
+            t.Fatalf("sources[%d] = %#v, want map", i, raw)
+        }
+        if src["token"] != maskedPlaceholder {
+            t.Errorf("sources[%d].token = %v, want masked", i, src["token"])
+        }
+        if src["endpoint"] == maskedPlaceholder || src["endpoint"] == "" {
+            t.Errorf("sources[%d].endpoint = %v, want untouched", i, src["endpoint"])
+        }
+    }
+}
@@ -0,0 +1,163 @@
+package config
+
+import (
+    "fmt"
+    "path/filepath"
+    "reflect"
+    "sync"
+    "sync/atomic"
+    "time"
+
+    "github.com/fsnotify/fsnotify"
+)
+
+// debounceInterval сглаживает серии событий от редакторов, которые
+// пересохраняют файл через rename/create вместо одного write.
+const debounceInterval = 200 * time.Millisecond
+
+// currentCfg хранит последний применённый конфиг, доступный через Current[T].
+var currentCfg atomic.Value
+
+func setCurrent(cfg any) {
+    currentCfg.Store(cfg)
+}
+
+// Current возвращает последний конфиг, загруженный через LoadConfig или
+// подтверждённый через Watch. Если конфиг ещё не загружался или имеет
+// другой тип, возвращается нулевое значение T.
+func Current[T any]() T {
+    v := currentCfg.Load()
+    if v == nil {
+        var zero T
+        return zero
+    }
+    if t, ok := v.(T); ok {
+        return t
+    }
+    var zero T
+    return zero
+}
+
+// Watch следит за базовым config.yml (см. findConfigFile) и при его
+// изменении перезагружает cfg. Это тонкая обёртка над WatchLoader с
+// Loader по умолчанию — если cfg изначально был загружен через кастомный
+// Loader (AddSearchPath/AddFile/APP_ENV-оверлей), используйте WatchLoader
+// с тем же Loader, иначе при hot-reload эти дополнительные слои потеряются.
+func Watch(cfg any, onChange func(old, new any) error) (stop func(), err error) {
+    return WatchLoader(NewLoader(), cfg, onChange)
+}
+
+// WatchLoader работает как Watch, но перезагружает cfg через l.build —
+// тот же конвейер слияния источников, что и l.Load (базовый config.yml,
+// APP_ENV-оверлей и все файлы, добавленные через l.AddFile). Это гарантирует,
+// что оверлей и AddFile-слои не исчезают на первом же hot-reload. Следятся
+// каталоги базового файла и всех l.AddFile-файлов; новая версия должна
+// пройти Validate и затем передаётся в onChange(old, new) — если Validate
+// или onChange вернёт ошибку, изменения отбрасываются и currentCfg не
+// меняется. Иначе новый конфиг становится доступен через Current[T].
+// Возвращённая stop останавливает наблюдение и освобождает watcher.
+func WatchLoader(l *Loader, cfg any, onChange func(old, new any) error) (stop func(), err error) {
+    cfgType := reflect.TypeOf(cfg)
+    if cfgType == nil || cfgType.Kind() != reflect.Ptr {
+        return nil, fmt.Errorf("config.Watch: cfg must be a non-nil pointer")
+    }
+
+    basePath, err := l.basePath()
+    if err != nil {
+        return nil, err
+    }
+
+    watchedPaths := map[string]struct{}{basePath: {}}
+    for _, f := range l.files {
+        watchedPaths[f] = struct{}{}
+    }
+    if overlay, ok := l.overlayPath(basePath); ok {
+        watchedPaths[overlay] = struct{}{}
+    }
+
+    watchedDirs := map[string]struct{}{}
+    for p := range watchedPaths {
+        watchedDirs[filepath.Dir(p)] = struct{}{}
+    }
+
+    watcher, err := fsnotify.NewWatcher()
+    if err != nil {
+        return nil, err
+    }
+    for dir := range watchedDirs {
+        if err := watcher.Add(dir); err != nil {
+            watcher.Close()
+            return nil, err
+        }
+    }
+
+    setCurrent(cfg)
+
+    reload := func() {
+        newCfg := reflect.New(cfgType.Elem()).Interface()
+        if err := l.build(newCfg); err != nil {
+            return
+        }
+        if err := Validate(newCfg); err != nil {
+            return
+        }
+        old := currentCfg.Load()
+        if err := onChange(old, newCfg); err != nil {
+            return
+        }
+        setCurrent(newCfg)
+    }
+
+    done := make(chan struct{})
+    var mu sync.Mutex
+    var debounce *time.Timer
+    var stopped bool
+
+    go func() {
+        for {
+            select {
+            case event, ok := <-watcher.Events:
+                if !ok {
+                    return
+                }
+                if _, watched := watchedPaths[event.Name]; !watched {
+                    continue
+                }
+                if event.Op&(fsnotify.Write|fsnotify.Create|fsnotify.Rename) == 0 {
+                    continue
+                }
+                mu.Lock()
+                if stopped {
+                    mu.Unlock()
+                    return
+                }
+                if debounce != nil {
+                    debounce.Stop()
+                }
+                debounce = time.AfterFunc(debounceInterval, reload)
+                mu.Unlock()
+            case _, ok := <-watcher.Errors:
+                if !ok {
+                    return
+                }
+            case <-done:
+                return
+            }
+        }
+    }()
+
+    var stopOnce sync.Once
+    stop = func() {
+        stopOnce.Do(func() {
+            mu.Lock()
+            stopped = true
+            if debounce != nil {
+                debounce.Stop()
+            }
+            mu.Unlock()
+            close(done)
+            watcher.Close()
+        })
+    }
+    return stop, nil
+}
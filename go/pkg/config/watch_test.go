@@ -0,0 +1,162 @@
+package config
+
+import (
+    "path/filepath"
+    "sync/atomic"
+    "testing"
+    "time"
+)
+
+type watchTestConfig struct {
+    Name  string `yaml:"name" validate:"required"`
+    Extra string `yaml:"extra"`
+}
+
+func pollUntil(t *testing.T, timeout time.Duration, cond func() bool) bool {
+    t.Helper()
+    deadline := time.Now().Add(timeout)
+    for time.Now().Before(deadline) {
+        if cond() {
+            return true
+        }
+        time.Sleep(10 * time.Millisecond)
+    }
+    return cond()
+}
+
+func TestWatchLoaderDebouncesRapidWrites(t *testing.T) {
+    t.Setenv("CONFIG_PATH", "")
+    t.Setenv("APP_ENV", "")
+
+    dir := t.TempDir()
+    configPath := filepath.Join(dir, "config.yml")
+    writeFile(t, configPath, "name: initial\n")
+
+    var calls int32
+    cfg := &watchTestConfig{Name: "initial"}
+    stop, err := WatchLoader(NewLoader().AddSearchPath(dir), cfg, func(old, new any) error {
+        atomic.AddInt32(&calls, 1)
+        return nil
+    })
+    if err != nil {
+        t.Fatalf("WatchLoader: %v", err)
+    }
+    defer stop()
+
+    // Several rapid writes inside the debounce window should collapse into
+    // a single reload.
+    for i := 0; i < 5; i++ {
+        writeFile(t, configPath, "name: updated\n")
+        time.Sleep(10 * time.Millisecond)
+    }
+
+    if !pollUntil(t, 2*time.Second, func() bool {
+        return Current[*watchTestConfig]().Name == "updated"
+    }) {
+        t.Fatal("config was never reloaded to the updated value")
+    }
+
+    // Give any further debounced reloads a chance to land before counting.
+    time.Sleep(3 * debounceInterval)
+    if got := atomic.LoadInt32(&calls); got != 1 {
+        t.Errorf("onChange called %d times, want exactly 1 (rapid writes should debounce)", got)
+    }
+}
+
+func TestWatchLoaderRejectsInvalidReload(t *testing.T) {
+    t.Setenv("CONFIG_PATH", "")
+    t.Setenv("APP_ENV", "")
+
+    dir := t.TempDir()
+    configPath := filepath.Join(dir, "config.yml")
+    writeFile(t, configPath, "name: initial\n")
+
+    var calls int32
+    cfg := &watchTestConfig{Name: "initial"}
+    stop, err := WatchLoader(NewLoader().AddSearchPath(dir), cfg, func(old, new any) error {
+        atomic.AddInt32(&calls, 1)
+        return nil
+    })
+    if err != nil {
+        t.Fatalf("WatchLoader: %v", err)
+    }
+    defer stop()
+
+    // name is required; clearing it must fail Validate and never publish.
+    writeFile(t, configPath, "name: \"\"\n")
+    time.Sleep(3 * debounceInterval)
+
+    if got := atomic.LoadInt32(&calls); got != 0 {
+        t.Errorf("onChange called %d times, want 0 for an invalid reload", got)
+    }
+    if got := Current[*watchTestConfig]().Name; got != "initial" {
+        t.Errorf("Current().Name = %q, want unchanged %q after a rejected reload", got, "initial")
+    }
+}
+
+func TestWatchLoaderStopPreventsPendingReload(t *testing.T) {
+    t.Setenv("CONFIG_PATH", "")
+    t.Setenv("APP_ENV", "")
+
+    dir := t.TempDir()
+    configPath := filepath.Join(dir, "config.yml")
+    writeFile(t, configPath, "name: initial\n")
+
+    var calls int32
+    cfg := &watchTestConfig{Name: "initial"}
+    stop, err := WatchLoader(NewLoader().AddSearchPath(dir), cfg, func(old, new any) error {
+        atomic.AddInt32(&calls, 1)
+        return nil
+    })
+    if err != nil {
+        t.Fatalf("WatchLoader: %v", err)
+    }
+
+    writeFile(t, configPath, "name: updated\n")
+    // Stop immediately, well inside the debounce window, before the pending
+    // reload fires.
+    stop()
+
+    time.Sleep(3 * debounceInterval)
+    if got := atomic.LoadInt32(&calls); got != 0 {
+        t.Errorf("onChange called %d times after stop(), want 0", got)
+    }
+    if got := Current[*watchTestConfig]().Name; got != "initial" {
+        t.Errorf("Current().Name = %q, want unchanged %q after stop() pre-empted the reload", got, "initial")
+    }
+
+    // stop() must be safe to call again.
+    stop()
+}
+
+func TestWatchLoaderPreservesAddFileLayerOnReload(t *testing.T) {
+    t.Setenv("CONFIG_PATH", "")
+    t.Setenv("APP_ENV", "")
+
+    dir := t.TempDir()
+    configPath := filepath.Join(dir, "config.yml")
+    writeFile(t, configPath, "name: initial\n")
+
+    extraPath := filepath.Join(dir, "extra.yml")
+    writeFile(t, extraPath, "extra: from-extra\n")
+
+    cfg := &watchTestConfig{Name: "initial"}
+    stop, err := WatchLoader(NewLoader().AddSearchPath(dir).AddFile(extraPath), cfg, func(old, new any) error {
+        return nil
+    })
+    if err != nil {
+        t.Fatalf("WatchLoader: %v", err)
+    }
+    defer stop()
+
+    writeFile(t, configPath, "name: updated\n")
+
+    if !pollUntil(t, 2*time.Second, func() bool {
+        return Current[*watchTestConfig]().Name == "updated"
+    }) {
+        t.Fatal("config was never reloaded to the updated value")
+    }
+    if got := Current[*watchTestConfig]().Extra; got != "from-extra" {
+        t.Errorf("Extra = %q, want %q (AddFile layer should survive hot-reload)", got, "from-extra")
+    }
+}